@@ -0,0 +1,441 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// FetchedPage is the LLM-friendly result of fetching and cleaning a URL.
+type FetchedPage struct {
+	Title    string
+	Markdown string
+	Links    []FetchedLink
+}
+
+// FetchedLink is an outbound link found on a fetched page.
+type FetchedLink struct {
+	URL  string
+	Text string
+}
+
+// blockedClassPattern matches class/id values that mark boilerplate
+// (navigation, sidebars, footers, comments) rather than article content.
+var blockedClassPattern = regexp.MustCompile(`(?i)comment|sidebar|footer|nav|menu|advert|promo|social|related`)
+
+// webFetchUserAgent is used for the single GET request webFetch issues;
+// it doesn't need the rotation pool the search providers use.
+const webFetchUserAgent = BrowserUserAgent
+
+// FetchURL fetches rawURL and returns its main content formatted for LLM
+// consumption: a Markdown rendering of the page's article content followed
+// by its outbound links. It is the entry point a web_fetch agent tool
+// should call; that tool registration doesn't exist in this package, so
+// nothing in this tree calls FetchURL yet.
+func FetchURL(ctx context.Context, client *http.Client, rawURL string) (string, error) {
+	page, err := webFetch(ctx, client, rawURL)
+	if err != nil {
+		return "", err
+	}
+	return formatFetchedPage(page), nil
+}
+
+// webFetch fetches rawURL and returns its main content as Markdown plus
+// its outbound links, using a simplified Readability-style scoring pass
+// to find the main-content node and drop nav/footer/ad boilerplate.
+func webFetch(ctx context.Context, client *http.Client, rawURL string) (*FetchedPage, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", webFetchUserAgent)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch url: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch failed with status code: %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	doc, err := html.Parse(strings.NewReader(string(body)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse HTML: %w", err)
+	}
+
+	title := findTitle(doc)
+	content := findMainContent(doc)
+	links := extractLinks(doc, rawURL)
+
+	return &FetchedPage{
+		Title:    title,
+		Markdown: strings.TrimSpace(nodeToMarkdown(content)),
+		Links:    links,
+	}, nil
+}
+
+// findTitle returns the document's <title> text.
+func findTitle(doc *html.Node) string {
+	var title string
+	var traverse func(*html.Node)
+	traverse = func(n *html.Node) {
+		if n.Type == html.ElementNode && n.Data == "title" {
+			title = getTextContent(n)
+			return
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			traverse(c)
+		}
+	}
+	traverse(doc)
+	return title
+}
+
+// findMainContent walks the DOM looking for <article>, <main>, and
+// high-text-density <div>/<section> candidates, scoring each on paragraph
+// count, text length, and link density, and returns the best-scoring node.
+// It falls back to <body> if nothing scores above zero.
+//
+// Scoring needs each candidate's descendant text length, paragraph count,
+// and link text length. Rather than re-walking a candidate's whole subtree
+// for each of those (which is quadratic on deeply nested real-world pages),
+// this accumulates all three bottom-up in the same traversal that looks
+// for candidates, so every node is visited once.
+func findMainContent(doc *html.Node) *html.Node {
+	var body *html.Node
+	var best *html.Node
+	bestScore := 0.0
+
+	var walk func(*html.Node) contentStats
+	walk = func(n *html.Node) contentStats {
+		stats := leafContentStats(n)
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			stats = stats.add(walk(c))
+		}
+		if n.Type != html.ElementNode {
+			return stats
+		}
+		stats = stats.withTag(n.Data)
+
+		if n.Data == "body" {
+			body = n
+		}
+		if isContentCandidate(n) {
+			if score := scoreFromStats(n, stats); score > bestScore {
+				bestScore = score
+				best = n
+			}
+		}
+		return stats
+	}
+	walk(doc)
+
+	if best != nil {
+		return best
+	}
+	return body
+}
+
+// isContentCandidate reports whether n is a tag type we score for
+// main-content, and isn't blocklisted by class/id.
+func isContentCandidate(n *html.Node) bool {
+	switch n.Data {
+	case "article", "main", "div", "section":
+	default:
+		return false
+	}
+	return !isBlocked(n)
+}
+
+// isBlocked reports whether n's class or id matches blockedClassPattern.
+func isBlocked(n *html.Node) bool {
+	for _, attr := range n.Attr {
+		if attr.Key == "class" || attr.Key == "id" {
+			if blockedClassPattern.MatchString(attr.Val) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// contentStats holds the signals scoreFromStats needs for a node's
+// subtree, accumulated bottom-up by findMainContent's single traversal
+// instead of being recomputed per-candidate.
+type contentStats struct {
+	textLen    int
+	paragraphs int
+	linkLen    int
+}
+
+// leafContentStats returns n's own contribution before its children are
+// folded in: text nodes contribute their length, everything else starts
+// at zero.
+func leafContentStats(n *html.Node) contentStats {
+	if n.Type == html.TextNode {
+		return contentStats{textLen: len(n.Data)}
+	}
+	return contentStats{}
+}
+
+// add folds a child's stats into s.
+func (s contentStats) add(child contentStats) contentStats {
+	s.textLen += child.textLen
+	s.paragraphs += child.paragraphs
+	s.linkLen += child.linkLen
+	return s
+}
+
+// withTag applies the effect element tag has on s, once all of its
+// children have already been folded in: <p> counts as a paragraph, and an
+// <a>'s full (already-accumulated) text counts as link text.
+func (s contentStats) withTag(tag string) contentStats {
+	switch tag {
+	case "p":
+		s.paragraphs++
+	case "a":
+		s.linkLen = s.textLen
+	}
+	return s
+}
+
+// computeContentStats walks n's subtree once to build the stats
+// scoreContentNode needs for standalone scoring of a single node.
+func computeContentStats(n *html.Node) contentStats {
+	stats := leafContentStats(n)
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		stats = stats.add(computeContentStats(c))
+	}
+	if n.Type == html.ElementNode {
+		stats = stats.withTag(n.Data)
+	}
+	return stats
+}
+
+// scoreContentNode scores n using paragraph count, text length, and an
+// inverse link-density penalty, the same signals Readability-style
+// extractors use to separate article text from boilerplate.
+func scoreContentNode(n *html.Node) float64 {
+	return scoreFromStats(n, computeContentStats(n))
+}
+
+// scoreFromStats scores n given its already-computed subtree stats.
+func scoreFromStats(n *html.Node, stats contentStats) float64 {
+	textLen := float64(stats.textLen)
+	if textLen < 100 {
+		return 0
+	}
+
+	linkDensity := 0.0
+	if textLen > 0 {
+		linkDensity = float64(stats.linkLen) / textLen
+	}
+
+	// Tag bonus rewards semantic containers; heavy link density
+	// (nav/footer lists of links) is penalized.
+	tagBonus := 1.0
+	if n.Data == "article" || n.Data == "main" {
+		tagBonus = 1.5
+	}
+
+	return (textLen + float64(stats.paragraphs)*25) * tagBonus * (1 - linkDensity)
+}
+
+// extractLinks collects every outbound <a href> on the page with its
+// anchor text, resolving relative URLs against base.
+func extractLinks(doc *html.Node, base string) []FetchedLink {
+	var links []FetchedLink
+	var traverse func(*html.Node)
+	traverse = func(n *html.Node) {
+		if n.Type == html.ElementNode && n.Data == "a" {
+			for _, attr := range n.Attr {
+				if attr.Key != "href" {
+					continue
+				}
+				href := resolveURL(base, attr.Val)
+				if href == "" {
+					continue
+				}
+				text := strings.TrimSpace(getTextContent(n))
+				if text == "" {
+					text = href
+				}
+				links = append(links, FetchedLink{URL: href, Text: text})
+				break
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			traverse(c)
+		}
+	}
+	traverse(doc)
+	return links
+}
+
+// nodeToMarkdown renders n's content as Markdown, preserving paragraphs,
+// headings, lists, code blocks, and tables.
+func nodeToMarkdown(n *html.Node) string {
+	if n == nil {
+		return ""
+	}
+	var sb strings.Builder
+	renderMarkdown(n, &sb)
+	return sb.String()
+}
+
+func renderMarkdown(n *html.Node, sb *strings.Builder) {
+	if n.Type == html.TextNode {
+		if text := strings.TrimSpace(n.Data); text != "" {
+			sb.WriteString(text)
+			sb.WriteString(" ")
+		}
+		return
+	}
+
+	if n.Type != html.ElementNode {
+		renderChildren(n, sb)
+		return
+	}
+
+	switch n.Data {
+	case "script", "style", "nav", "footer":
+		return
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		level := int(n.Data[1] - '0')
+		sb.WriteString("\n" + strings.Repeat("#", level) + " " + strings.TrimSpace(getTextContent(n)) + "\n\n")
+	case "p":
+		sb.WriteString("\n")
+		renderChildren(n, sb)
+		sb.WriteString("\n\n")
+	case "br":
+		sb.WriteString("\n")
+	case "li":
+		sb.WriteString("\n- ")
+		renderChildren(n, sb)
+	case "a":
+		text := strings.TrimSpace(getTextContent(n))
+		href := attrValue(n, "href")
+		if href != "" && text != "" {
+			sb.WriteString(fmt.Sprintf("[%s](%s)", text, href))
+		} else {
+			renderChildren(n, sb)
+		}
+	case "pre":
+		sb.WriteString("\n```\n" + getTextContent(n) + "\n```\n\n")
+	case "code":
+		sb.WriteString("`" + getTextContent(n) + "`")
+	case "table":
+		renderTable(n, sb)
+	default:
+		renderChildren(n, sb)
+	}
+}
+
+func renderChildren(n *html.Node, sb *strings.Builder) {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		renderMarkdown(c, sb)
+	}
+}
+
+// renderTable renders a <table> as GitHub-flavored Markdown, emitting the
+// first row as the header.
+func renderTable(n *html.Node, sb *strings.Builder) {
+	var rows [][]string
+	var traverseRows func(*html.Node)
+	traverseRows = func(node *html.Node) {
+		if node.Type == html.ElementNode && node.Data == "tr" {
+			var cells []string
+			for c := node.FirstChild; c != nil; c = c.NextSibling {
+				if c.Type == html.ElementNode && (c.Data == "td" || c.Data == "th") {
+					cells = append(cells, strings.TrimSpace(getTextContent(c)))
+				}
+			}
+			if len(cells) > 0 {
+				rows = append(rows, cells)
+			}
+			return
+		}
+		for c := node.FirstChild; c != nil; c = c.NextSibling {
+			traverseRows(c)
+		}
+	}
+	traverseRows(n)
+
+	if len(rows) == 0 {
+		return
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString("| " + strings.Join(rows[0], " | ") + " |\n")
+	sb.WriteString("|" + strings.Repeat(" --- |", len(rows[0])) + "\n")
+	for _, row := range rows[1:] {
+		sb.WriteString("| " + strings.Join(row, " | ") + " |\n")
+	}
+	sb.WriteString("\n")
+}
+
+func attrValue(n *html.Node, key string) string {
+	for _, attr := range n.Attr {
+		if attr.Key == key {
+			return attr.Val
+		}
+	}
+	return ""
+}
+
+// resolveURL resolves ref against base, dropping non-http(s) links
+// (mailto:, javascript:, anchors).
+func resolveURL(base, ref string) string {
+	ref = strings.TrimSpace(ref)
+	if ref == "" || strings.HasPrefix(ref, "#") {
+		return ""
+	}
+
+	baseURL, err := url.Parse(base)
+	if err != nil {
+		return ""
+	}
+	refURL, err := url.Parse(ref)
+	if err != nil {
+		return ""
+	}
+
+	resolved := baseURL.ResolveReference(refURL)
+	if resolved.Scheme != "http" && resolved.Scheme != "https" {
+		return ""
+	}
+	return resolved.String()
+}
+
+// formatFetchedPage formats a fetched page for LLM consumption: the
+// cleaned Markdown content followed by a list of outbound links.
+func formatFetchedPage(page *FetchedPage) string {
+	var sb strings.Builder
+
+	if page.Title != "" {
+		sb.WriteString(fmt.Sprintf("# %s\n\n", page.Title))
+	}
+	sb.WriteString(page.Markdown)
+
+	if len(page.Links) > 0 {
+		sb.WriteString("\n\n## Links\n\n")
+		for _, link := range page.Links {
+			sb.WriteString(fmt.Sprintf("- [%s](%s)\n", link.Text, link.URL))
+		}
+	}
+
+	return sb.String()
+}
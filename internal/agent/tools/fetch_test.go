@@ -0,0 +1,94 @@
+package tools
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+func parseFragment(t *testing.T, fragment string) *html.Node {
+	t.Helper()
+	doc, err := html.Parse(strings.NewReader(fragment))
+	if err != nil {
+		t.Fatalf("failed to parse fragment: %v", err)
+	}
+	return doc
+}
+
+func TestScoreContentNodePrefersArticleOverBoilerplate(t *testing.T) {
+	article := strings.Repeat("This is some real article content. ", 10)
+	doc := parseFragment(t, `<html><body>
+<nav><a href="/a">Home</a><a href="/b">About</a><a href="/c">Contact</a></nav>
+<article><p>`+article+`</p><p>`+article+`</p></article>
+</body></html>`)
+
+	content := findMainContent(doc)
+	if content == nil || content.Data != "article" {
+		t.Fatalf("findMainContent returned %v, want the <article> node", content)
+	}
+
+	articleScore := scoreContentNode(content)
+	if articleScore <= 0 {
+		t.Errorf("scoreContentNode(article) = %v, want > 0", articleScore)
+	}
+}
+
+func TestScoreContentNodeBelowMinLengthIsZero(t *testing.T) {
+	doc := parseFragment(t, `<div>too short</div>`)
+	var div *html.Node
+	var find func(*html.Node)
+	find = func(n *html.Node) {
+		if n.Type == html.ElementNode && n.Data == "div" {
+			div = n
+			return
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			find(c)
+		}
+	}
+	find(doc)
+
+	if got := scoreContentNode(div); got != 0 {
+		t.Errorf("scoreContentNode(short div) = %v, want 0", got)
+	}
+}
+
+func TestNodeToMarkdown(t *testing.T) {
+	doc := parseFragment(t, `<div>
+<h1>Title</h1>
+<p>Hello <a href="https://example.com">world</a>.</p>
+<ul><li>one</li><li>two</li></ul>
+<pre>code block</pre>
+</div>`)
+
+	got := nodeToMarkdown(doc)
+
+	for _, want := range []string{
+		"# Title",
+		"Hello [world](https://example.com).",
+		"- one",
+		"- two",
+		"```\ncode block\n```",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("nodeToMarkdown output missing %q, got:\n%s", want, got)
+		}
+	}
+}
+
+func TestNodeToMarkdownDropsScriptsAndNav(t *testing.T) {
+	doc := parseFragment(t, `<div>
+<script>alert('x')</script>
+<nav><a href="/a">skip me</a></nav>
+<p>keep me</p>
+</div>`)
+
+	got := nodeToMarkdown(doc)
+	if strings.Contains(got, "alert") || strings.Contains(got, "skip me") {
+		t.Errorf("nodeToMarkdown should drop script/nav content, got:\n%s", got)
+	}
+	if !strings.Contains(got, "keep me") {
+		t.Errorf("nodeToMarkdown dropped real content, got:\n%s", got)
+	}
+}
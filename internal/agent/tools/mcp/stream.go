@@ -0,0 +1,154 @@
+package mcp
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/charmbracelet/crush/internal/csync"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ToolEvent is one incremental event from a streamed MCP tool call: either
+// a progress update or a chunk of the eventual result.
+type ToolEvent struct {
+	Type      string // "progress", "text", "image", "audio", or "error"
+	Content   string
+	Data      []byte
+	MediaType string
+
+	// Progress and Total are only set when Type == "progress". Total is
+	// 0 when the server didn't report an expected total.
+	Progress float64
+	Total    float64
+	Message  string
+}
+
+// progressSink delivers ToolEvents to a channel while it's open, and
+// silently drops them afterwards instead of panicking on a closed
+// channel. A progress notification can arrive concurrently with the call
+// it belongs to completing, so close and send are both synchronized
+// through mu.
+type progressSink struct {
+	mu     sync.Mutex
+	ch     chan ToolEvent
+	closed bool
+}
+
+func newProgressSink(buffer int) *progressSink {
+	return &progressSink{ch: make(chan ToolEvent, buffer)}
+}
+
+func (s *progressSink) send(event ToolEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return
+	}
+	s.ch <- event
+}
+
+func (s *progressSink) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return
+	}
+	s.closed = true
+	close(s.ch)
+}
+
+// progressSinks maps an in-flight call's progress token to the sink its
+// ToolEvents should be delivered on. It's populated for the duration of a
+// single RunToolStream call and looked up by DispatchProgress.
+//
+// DispatchProgress must be wired in as mcp.ClientOptions.ProgressNotificationHandler
+// wherever the MCP client is constructed for progress notifications to
+// actually reach it; that construction code lives outside this package and
+// isn't part of this change.
+var progressSinks = csync.NewMap[string, *progressSink]()
+
+// DispatchProgress forwards an MCP progress notification to the sink
+// registered for its progress token, if any.
+func DispatchProgress(_ context.Context, req *mcp.ProgressNotificationClientRequest) {
+	token := fmt.Sprintf("%v", req.Params.ProgressToken)
+	sink, ok := progressSinks.Get(token)
+	if !ok {
+		return
+	}
+	sink.send(ToolEvent{
+		Type:     "progress",
+		Progress: req.Params.Progress,
+		Total:    req.Params.Total,
+		Message:  req.Params.Message,
+	})
+}
+
+// RunToolStream runs an MCP tool like RunTool, but returns a channel of
+// incremental ToolEvents instead of blocking until the final result:
+// progress notifications are forwarded as they arrive, and the call's
+// text/image/audio content is sent as a final event before the channel is
+// closed. Cancelling ctx stops the call and causes the MCP client to send
+// notifications/cancelled to the server.
+func RunToolStream(ctx context.Context, name, toolName, input string) (<-chan ToolEvent, error) {
+	var args map[string]any
+	if err := json.Unmarshal([]byte(input), &args); err != nil {
+		return nil, fmt.Errorf("error parsing parameters: %s", err)
+	}
+
+	c, err := getOrRenewClient(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := newProgressToken()
+	if err != nil {
+		return nil, err
+	}
+
+	sink := newProgressSink(8)
+	progressSinks.Set(token, sink)
+
+	go func() {
+		defer sink.close()
+		defer progressSinks.Del(token)
+
+		result, err := c.CallTool(ctx, &mcp.CallToolParams{
+			Name:      toolName,
+			Arguments: args,
+			Meta:      mcp.Meta{"progressToken": token},
+		})
+		if err != nil {
+			sink.send(ToolEvent{Type: "error", Content: err.Error()})
+			return
+		}
+
+		for _, v := range result.Content {
+			switch content := v.(type) {
+			case *mcp.TextContent:
+				sink.send(ToolEvent{Type: "text", Content: content.Text})
+			case *mcp.ImageContent:
+				sink.send(ToolEvent{Type: "image", Data: content.Data, MediaType: content.MIMEType})
+			case *mcp.AudioContent:
+				sink.send(ToolEvent{Type: "audio", Data: content.Data, MediaType: content.MIMEType})
+			default:
+				sink.send(ToolEvent{Type: "text", Content: fmt.Sprintf("%v", v)})
+			}
+		}
+	}()
+
+	return sink.ch, nil
+}
+
+// newProgressToken generates a random token to correlate progress
+// notifications with the call that requested them.
+func newProgressToken() (string, error) {
+	raw := make([]byte, 8)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("failed to generate progress token: %w", err)
+	}
+	return hex.EncodeToString(raw), nil
+}
@@ -3,16 +3,10 @@ package tools
 import (
 	"context"
 	"fmt"
-	"io"
 	"net/http"
-	"net/url"
-	"slices"
-	"strings"
-
-	"golang.org/x/net/html"
 )
 
-// SearchResult represents a single search result from DuckDuckGo.
+// SearchResult represents a single search result returned by a SearchProvider.
 type SearchResult struct {
 	Title    string
 	Link     string
@@ -20,164 +14,88 @@ type SearchResult struct {
 	Position int
 }
 
-// searchDuckDuckGo performs a web search using DuckDuckGo's HTML endpoint.
-func searchDuckDuckGo(ctx context.Context, client *http.Client, query string, maxResults int) ([]SearchResult, error) {
-	if maxResults <= 0 {
-		maxResults = 10
-	}
-
-	formData := url.Values{}
-	formData.Set("q", query)
-	formData.Set("b", "")
-	formData.Set("kl", "")
-
-	req, err := http.NewRequestWithContext(ctx, "POST", "https://html.duckduckgo.com/html", strings.NewReader(formData.Encode()))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-	req.Header.Set("User-Agent", BrowserUserAgent)
-
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to execute search: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("search failed with status code: %d", resp.StatusCode)
-	}
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
-	}
-
-	return parseSearchResults(string(body), maxResults)
+// SearchProvider performs a web search and returns a list of results.
+//
+// Implementations are free to scrape HTML, call a JSON API, or drive a
+// headless browser; callers only depend on this interface so the backend
+// can be swapped via config without touching the agent tool itself.
+type SearchProvider interface {
+	// Search runs the query and returns up to maxResults results.
+	Search(ctx context.Context, query string, maxResults int) ([]SearchResult, error)
 }
 
-// parseSearchResults extracts search results from DuckDuckGo HTML response.
-func parseSearchResults(htmlContent string, maxResults int) ([]SearchResult, error) {
-	doc, err := html.Parse(strings.NewReader(htmlContent))
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse HTML: %w", err)
+// defaultSearchBackend is used when providers.search.backend is unset.
+const defaultSearchBackend = "duckduckgo"
+
+// NewSearchProvider builds the SearchProvider configured for backend, using
+// client for any outgoing HTTP requests the provider needs. backend is
+// meant to be read from providers.search.backend by whatever registers the
+// search tool with the agent; that registration doesn't exist in this
+// package, so nothing in this tree calls NewSearchProvider yet.
+//
+// Plain-HTML backends (duckduckgo, searxng) are wrapped so that a search
+// returning zero results falls back to rendering the results page in a
+// headless browser via chromedp, which handles JS-heavy pages the HTML
+// scrape can't see. Requesting "chromedp" directly skips the wrapping,
+// since there's nothing left to fall back to.
+func NewSearchProvider(backend string, client *http.Client) (SearchProvider, error) {
+	if backend == "" {
+		backend = defaultSearchBackend
 	}
-
-	var results []SearchResult
-	var traverse func(*html.Node)
-
-	traverse = func(n *html.Node) {
-		if n.Type == html.ElementNode && n.Data == "div" && hasClass(n, "result") {
-			result := extractResult(n)
-			if result != nil && result.Link != "" && !strings.Contains(result.Link, "y.js") {
-				result.Position = len(results) + 1
-				results = append(results, *result)
-				if len(results) >= maxResults {
-					return
-				}
-			}
-		}
-		for c := n.FirstChild; c != nil && len(results) < maxResults; c = c.NextSibling {
-			traverse(c)
-		}
+	if client == nil {
+		client = http.DefaultClient
 	}
 
-	traverse(doc)
-	return results, nil
-}
-
-// hasClass checks if an HTML node has a specific class.
-func hasClass(n *html.Node, class string) bool {
-	for _, attr := range n.Attr {
-		if attr.Key == "class" {
-			return slices.Contains(strings.Fields(attr.Val), class)
-		}
+	switch backend {
+	case "duckduckgo":
+		return withChromedpFallback(newDuckDuckGoProvider(client)), nil
+	case "searxng":
+		return withChromedpFallback(newSearxngProvider(client)), nil
+	case "chromedp":
+		return newChromedpProvider(), nil
+	default:
+		return nil, fmt.Errorf("unknown search backend: %s", backend)
 	}
-	return false
 }
 
-// extractResult extracts a search result from a result div node.
-func extractResult(n *html.Node) *SearchResult {
-	result := &SearchResult{}
-
-	var traverse func(*html.Node)
-	traverse = func(node *html.Node) {
-		if node.Type == html.ElementNode {
-			// Look for title link.
-			if node.Data == "a" && hasClass(node, "result__a") {
-				result.Title = getTextContent(node)
-				for _, attr := range node.Attr {
-					if attr.Key == "href" {
-						result.Link = cleanDuckDuckGoURL(attr.Val)
-						break
-					}
-				}
-			}
-			// Look for snippet.
-			if node.Data == "a" && hasClass(node, "result__snippet") {
-				result.Snippet = getTextContent(node)
-			}
-		}
-		for c := node.FirstChild; c != nil; c = c.NextSibling {
-			traverse(c)
-		}
-	}
-
-	traverse(n)
-	return result
+// withChromedpFallback wraps primary so that a zero-result search is retried
+// once against a headless-browser render of the results page.
+func withChromedpFallback(primary SearchProvider) SearchProvider {
+	return &cascadingProvider{primary: primary, fallback: newChromedpProvider()}
 }
 
-// getTextContent extracts all text content from a node and its children.
-func getTextContent(n *html.Node) string {
-	var text strings.Builder
-	var traverse func(*html.Node)
-
-	traverse = func(node *html.Node) {
-		if node.Type == html.TextNode {
-			text.WriteString(node.Data)
-		}
-		for c := node.FirstChild; c != nil; c = c.NextSibling {
-			traverse(c)
-		}
-	}
-
-	traverse(n)
-	return strings.TrimSpace(text.String())
+// cascadingProvider runs primary first and only falls through to fallback
+// when primary succeeds but finds nothing — a primary error is returned
+// as-is, since a failed request (timeout, bad response) isn't the
+// JS-rendering gap fallback exists for.
+type cascadingProvider struct {
+	primary  SearchProvider
+	fallback SearchProvider
 }
 
-// cleanDuckDuckGoURL extracts the actual URL from DuckDuckGo's redirect URL.
-func cleanDuckDuckGoURL(rawURL string) string {
-	if strings.HasPrefix(rawURL, "//duckduckgo.com/l/?uddg=") {
-		// Extract the actual URL from the redirect.
-		if idx := strings.Index(rawURL, "uddg="); idx != -1 {
-			encoded := rawURL[idx+5:]
-			if ampIdx := strings.Index(encoded, "&"); ampIdx != -1 {
-				encoded = encoded[:ampIdx]
-			}
-			decoded, err := url.QueryUnescape(encoded)
-			if err == nil {
-				return decoded
-			}
-		}
+func (p *cascadingProvider) Search(ctx context.Context, query string, maxResults int) ([]SearchResult, error) {
+	results, err := p.primary.Search(ctx, query, maxResults)
+	if err != nil {
+		return nil, err
+	}
+	if len(results) > 0 {
+		return results, nil
 	}
-	return rawURL
+	return p.fallback.Search(ctx, query, maxResults)
 }
 
 // formatSearchResults formats search results for LLM consumption.
 func formatSearchResults(results []SearchResult) string {
 	if len(results) == 0 {
-		return "No results were found for your search query. This could be due to DuckDuckGo's bot detection or the query returned no matches. Please try rephrasing your search or try again in a few minutes."
+		return "No results were found for your search query. This could be due to the search backend's bot detection or the query returned no matches. Please try rephrasing your search or try again in a few minutes."
 	}
 
-	var sb strings.Builder
-	sb.WriteString(fmt.Sprintf("Found %d search results:\n\n", len(results)))
-
+	out := fmt.Sprintf("Found %d search results:\n\n", len(results))
 	for _, result := range results {
-		sb.WriteString(fmt.Sprintf("%d. %s\n", result.Position, result.Title))
-		sb.WriteString(fmt.Sprintf("   URL: %s\n", result.Link))
-		sb.WriteString(fmt.Sprintf("   Summary: %s\n\n", result.Snippet))
+		out += fmt.Sprintf("%d. %s\n", result.Position, result.Title)
+		out += fmt.Sprintf("   URL: %s\n", result.Link)
+		out += fmt.Sprintf("   Summary: %s\n\n", result.Snippet)
 	}
 
-	return sb.String()
+	return out
 }
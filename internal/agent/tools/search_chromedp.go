@@ -0,0 +1,64 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/chromedp/chromedp"
+)
+
+// chromedpTimeout bounds how long the headless browser is given to render
+// a results page before giving up.
+const chromedpTimeout = 20 * time.Second
+
+// chromedpProvider renders search result pages in a headless browser
+// before parsing them. It exists as a fallback for JS-heavy result pages
+// where plain-HTML scraping returns zero results.
+type chromedpProvider struct {
+	ua *userAgentRotator
+}
+
+func newChromedpProvider() *chromedpProvider {
+	return &chromedpProvider{ua: defaultUserAgentRotator}
+}
+
+// Search renders the DuckDuckGo results page with a headless browser and
+// parses the resulting DOM the same way the plain-HTML provider does.
+func (p *chromedpProvider) Search(ctx context.Context, query string, maxResults int) ([]SearchResult, error) {
+	if maxResults <= 0 {
+		maxResults = 10
+	}
+
+	allocCtx, cancelAlloc := chromedp.NewExecAllocator(ctx, append(
+		chromedp.DefaultExecAllocatorOptions[:],
+		chromedp.UserAgent(p.ua.Next()),
+	)...)
+	defer cancelAlloc()
+
+	browserCtx, cancelBrowser := chromedp.NewContext(allocCtx)
+	defer cancelBrowser()
+
+	browserCtx, cancelTimeout := context.WithTimeout(browserCtx, chromedpTimeout)
+	defer cancelTimeout()
+
+	searchURL := fmt.Sprintf("https://duckduckgo.com/html/?q=%s", query)
+
+	var rendered string
+	if err := chromedp.Run(browserCtx,
+		chromedp.Navigate(searchURL),
+		chromedp.WaitVisible("body", chromedp.ByQuery),
+		chromedp.OuterHTML("html", &rendered, chromedp.ByQuery),
+	); err != nil {
+		return nil, fmt.Errorf("failed to render search results: %w", err)
+	}
+
+	results, err := parseDuckDuckGoResults(rendered, maxResults)
+	if err != nil {
+		return nil, err
+	}
+	if len(results) == 0 {
+		return nil, fmt.Errorf("no results found in rendered page")
+	}
+	return results, nil
+}
@@ -0,0 +1,78 @@
+package tools
+
+import "testing"
+
+const duckDuckGoResultHTML = `
+<html><body>
+<div class="result results_links results_links_deep web-result">
+  <a class="result__a" href="https://example.com/a">Example A</a>
+  <a class="result__snippet">First result snippet.</a>
+</div>
+<div class="result results_links results_links_deep web-result">
+  <a class="result__a" href="//duckduckgo.com/l/?uddg=https%3A%2F%2Fexample.com%2Fb&amp;rut=1">Example B</a>
+  <a class="result__snippet">Second result snippet.</a>
+</div>
+<div class="result results_links results_links_deep web-result">
+  <a class="result__a" href="https://example.com/tracked/y.js">Tracked</a>
+  <a class="result__snippet">Should be skipped.</a>
+</div>
+</body></html>`
+
+func TestParseDuckDuckGoResults(t *testing.T) {
+	results, err := parseDuckDuckGoResults(duckDuckGoResultHTML, 10)
+	if err != nil {
+		t.Fatalf("parseDuckDuckGoResults returned error: %v", err)
+	}
+
+	if len(results) != 2 {
+		t.Fatalf("got %d results, want 2", len(results))
+	}
+
+	if results[0].Title != "Example A" || results[0].Link != "https://example.com/a" {
+		t.Errorf("result[0] = %+v, want title %q link %q", results[0], "Example A", "https://example.com/a")
+	}
+	if results[0].Position != 1 {
+		t.Errorf("result[0].Position = %d, want 1", results[0].Position)
+	}
+
+	if results[1].Link != "https://example.com/b" {
+		t.Errorf("result[1].Link = %q, want decoded redirect target %q", results[1].Link, "https://example.com/b")
+	}
+}
+
+func TestParseDuckDuckGoResultsRespectsMaxResults(t *testing.T) {
+	results, err := parseDuckDuckGoResults(duckDuckGoResultHTML, 1)
+	if err != nil {
+		t.Fatalf("parseDuckDuckGoResults returned error: %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("got %d results, want 1", len(results))
+	}
+}
+
+func TestCleanDuckDuckGoURL(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "plain url passes through",
+			in:   "https://example.com/page",
+			want: "https://example.com/page",
+		},
+		{
+			name: "redirect url is decoded",
+			in:   "//duckduckgo.com/l/?uddg=https%3A%2F%2Fexample.com%2Fpage&rut=1",
+			want: "https://example.com/page",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cleanDuckDuckGoURL(tt.in); got != tt.want {
+				t.Errorf("cleanDuckDuckGoURL(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,291 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// searxngInstancePoolTTL controls how long the ranked instance pool is
+// reused before searx.space is re-queried.
+const searxngInstancePoolTTL = 30 * time.Minute
+
+// searxngInstanceBackoff is how long a failing instance is skipped for
+// after it errors out.
+const searxngInstanceBackoff = 10 * time.Minute
+
+// searxngInstance is a health-checked SearXNG instance candidate.
+type searxngInstance struct {
+	URL             string
+	ResponseTime    time.Duration
+	SupportsJSONAPI bool
+
+	mu           sync.Mutex
+	backoffUntil time.Time
+}
+
+func (i *searxngInstance) available(now time.Time) bool {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return now.After(i.backoffUntil)
+}
+
+func (i *searxngInstance) markFailure(now time.Time) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.backoffUntil = now.Add(searxngInstanceBackoff)
+}
+
+// searxngProvider searches by rotating across a pool of public SearXNG
+// instances, falling back to the next instance when one fails or is in
+// backoff.
+type searxngProvider struct {
+	client *http.Client
+	ua     *userAgentRotator
+
+	mu          sync.Mutex
+	pool        []*searxngInstance
+	poolFetched time.Time
+	poolIndex   int
+}
+
+func newSearxngProvider(client *http.Client) *searxngProvider {
+	return &searxngProvider{client: client, ua: defaultUserAgentRotator}
+}
+
+// Search queries the next healthy SearXNG instance in the pool, rotating to
+// the following one on failure.
+func (p *searxngProvider) Search(ctx context.Context, query string, maxResults int) ([]SearchResult, error) {
+	if maxResults <= 0 {
+		maxResults = 10
+	}
+
+	instances, err := p.instancePool(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(instances) == 0 {
+		return nil, fmt.Errorf("no healthy searxng instances available")
+	}
+
+	now := time.Now()
+	var lastErr error
+	for range instances {
+		inst := p.nextInstance(instances)
+		if !inst.available(now) {
+			continue
+		}
+
+		results, err := p.searchInstance(ctx, inst, query, maxResults)
+		if err != nil {
+			inst.markFailure(now)
+			lastErr = err
+			continue
+		}
+		return results, nil
+	}
+
+	if lastErr == nil {
+		lastErr = fmt.Errorf("all searxng instances are in backoff")
+	}
+	return nil, lastErr
+}
+
+// nextInstance returns the next instance round-robin from the pool.
+func (p *searxngProvider) nextInstance(instances []*searxngInstance) *searxngInstance {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	inst := instances[p.poolIndex%len(instances)]
+	p.poolIndex++
+	return inst
+}
+
+// instancePool returns the cached ranked instance pool, refreshing it from
+// searx.space if it is stale.
+func (p *searxngProvider) instancePool(ctx context.Context) ([]*searxngInstance, error) {
+	p.mu.Lock()
+	if len(p.pool) > 0 && time.Since(p.poolFetched) < searxngInstancePoolTTL {
+		pool := p.pool
+		p.mu.Unlock()
+		return pool, nil
+	}
+	p.mu.Unlock()
+
+	candidates, err := fetchSearxSpaceInstances(ctx, p.client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch searxng instance list: %w", err)
+	}
+
+	healthy := p.healthCheck(ctx, candidates)
+	sort.Slice(healthy, func(i, j int) bool {
+		return healthy[i].ResponseTime < healthy[j].ResponseTime
+	})
+
+	p.mu.Lock()
+	p.pool = healthy
+	p.poolFetched = time.Now()
+	p.poolIndex = 0
+	p.mu.Unlock()
+
+	return healthy, nil
+}
+
+// healthCheck probes each candidate URL for HTTPS, response time, and JSON
+// API support, returning only those that pass.
+func (p *searxngProvider) healthCheck(ctx context.Context, candidates []string) []*searxngInstance {
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		healthy []*searxngInstance
+	)
+
+	for _, url := range candidates {
+		wg.Add(1)
+		go func(url string) {
+			defer wg.Done()
+			inst, ok := p.probe(ctx, url)
+			if !ok {
+				return
+			}
+			mu.Lock()
+			healthy = append(healthy, inst)
+			mu.Unlock()
+		}(url)
+	}
+	wg.Wait()
+
+	return healthy
+}
+
+// probe checks a single SearXNG instance for HTTPS, latency, and JSON API
+// support by issuing a lightweight search request.
+func (p *searxngProvider) probe(ctx context.Context, baseURL string) (*searxngInstance, bool) {
+	if len(baseURL) < len("https://") || baseURL[:8] != "https://" {
+		return nil, false
+	}
+
+	probeCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	start := time.Now()
+	req, err := http.NewRequestWithContext(probeCtx, http.MethodGet, baseURL+"/search?q=test&format=json", nil)
+	if err != nil {
+		return nil, false
+	}
+	req.Header.Set("User-Agent", p.ua.Next())
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, false
+	}
+	defer resp.Body.Close()
+
+	elapsed := time.Since(start)
+	if resp.StatusCode != http.StatusOK {
+		return nil, false
+	}
+
+	var payload struct {
+		Results []json.RawMessage `json:"results"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return nil, false
+	}
+
+	return &searxngInstance{
+		URL:             baseURL,
+		ResponseTime:    elapsed,
+		SupportsJSONAPI: true,
+	}, true
+}
+
+// searchInstance runs query against a single SearXNG instance's JSON API.
+func (p *searxngProvider) searchInstance(ctx context.Context, inst *searxngInstance, query string, maxResults int) ([]SearchResult, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, inst.URL+"/search", nil)
+	if err != nil {
+		return nil, err
+	}
+	q := req.URL.Query()
+	q.Set("q", query)
+	q.Set("format", "json")
+	req.URL.RawQuery = q.Encode()
+	req.Header.Set("User-Agent", p.ua.Next())
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("searxng instance %s returned status %d", inst.URL, resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var payload struct {
+		Results []struct {
+			Title   string `json:"title"`
+			URL     string `json:"url"`
+			Content string `json:"content"`
+		} `json:"results"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("failed to parse searxng response: %w", err)
+	}
+
+	var results []SearchResult
+	for _, r := range payload.Results {
+		results = append(results, SearchResult{
+			Title:    r.Title,
+			Link:     r.URL,
+			Snippet:  r.Content,
+			Position: len(results) + 1,
+		})
+		if len(results) >= maxResults {
+			break
+		}
+	}
+
+	return results, nil
+}
+
+// fetchSearxSpaceInstances fetches the list of public SearXNG instance URLs
+// from searx.space's instance directory.
+func fetchSearxSpaceInstances(ctx context.Context, client *http.Client) ([]string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://searx.space/data/instances.json", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("searx.space returned status %d", resp.StatusCode)
+	}
+
+	var payload struct {
+		Instances map[string]json.RawMessage `json:"instances"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return nil, err
+	}
+
+	urls := make([]string, 0, len(payload.Instances))
+	for url := range payload.Instances {
+		urls = append(urls, url)
+	}
+	return urls, nil
+}
@@ -0,0 +1,67 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type stubSearchProvider struct {
+	results []SearchResult
+	err     error
+	calls   int
+}
+
+func (s *stubSearchProvider) Search(ctx context.Context, query string, maxResults int) ([]SearchResult, error) {
+	s.calls++
+	return s.results, s.err
+}
+
+func TestCascadingProviderFallsBackOnZeroResults(t *testing.T) {
+	primary := &stubSearchProvider{}
+	fallback := &stubSearchProvider{results: []SearchResult{{Title: "from fallback"}}}
+	p := &cascadingProvider{primary: primary, fallback: fallback}
+
+	results, err := p.Search(context.Background(), "query", 10)
+	if err != nil {
+		t.Fatalf("Search returned error: %v", err)
+	}
+	if len(results) != 1 || results[0].Title != "from fallback" {
+		t.Errorf("Search() = %+v, want fallback's single result", results)
+	}
+	if primary.calls != 1 || fallback.calls != 1 {
+		t.Errorf("primary.calls = %d, fallback.calls = %d, want 1 and 1", primary.calls, fallback.calls)
+	}
+}
+
+func TestCascadingProviderSkipsFallbackOnSuccess(t *testing.T) {
+	primary := &stubSearchProvider{results: []SearchResult{{Title: "from primary"}}}
+	fallback := &stubSearchProvider{results: []SearchResult{{Title: "from fallback"}}}
+	p := &cascadingProvider{primary: primary, fallback: fallback}
+
+	results, err := p.Search(context.Background(), "query", 10)
+	if err != nil {
+		t.Fatalf("Search returned error: %v", err)
+	}
+	if len(results) != 1 || results[0].Title != "from primary" {
+		t.Errorf("Search() = %+v, want primary's result", results)
+	}
+	if fallback.calls != 0 {
+		t.Errorf("fallback.calls = %d, want 0 (primary succeeded)", fallback.calls)
+	}
+}
+
+func TestCascadingProviderPropagatesPrimaryError(t *testing.T) {
+	wantErr := errors.New("boom")
+	primary := &stubSearchProvider{err: wantErr}
+	fallback := &stubSearchProvider{results: []SearchResult{{Title: "from fallback"}}}
+	p := &cascadingProvider{primary: primary, fallback: fallback}
+
+	_, err := p.Search(context.Background(), "query", 10)
+	if !errors.Is(err, wantErr) {
+		t.Errorf("Search() error = %v, want %v", err, wantErr)
+	}
+	if fallback.calls != 0 {
+		t.Errorf("fallback.calls = %d, want 0 (primary errored, not empty)", fallback.calls)
+	}
+}
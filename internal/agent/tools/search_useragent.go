@@ -0,0 +1,101 @@
+package tools
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// BrowserUserAgent is the first entry of the fallback rotation pool and the
+// fixed User-Agent webFetch sends.
+const BrowserUserAgent = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36"
+
+// fallbackUserAgents is the static seed pool used until (and unless) a
+// UserAgentSource refreshes it via StartUserAgentRefresher. Entries will
+// gradually go stale, so anything long-running should wire up a refresher
+// rather than relying on this list alone.
+var fallbackUserAgents = []string{
+	BrowserUserAgent,
+	"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.4 Safari/605.1.15",
+	"Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36",
+	"Mozilla/5.0 (Windows NT 10.0; Win64; x64; rv:125.0) Gecko/20100101 Firefox/125.0",
+}
+
+// userAgentRotator hands out User-Agent strings round-robin from a pool.
+// It exists so scraping providers don't all hammer a search backend with
+// the same fingerprint. The pool can be swapped out at runtime by
+// StartUserAgentRefresher, so reads go through an atomic pointer rather
+// than a plain slice field.
+type userAgentRotator struct {
+	agents atomic.Pointer[[]string]
+	next   atomic.Uint64
+}
+
+// defaultUserAgentRotator is shared by providers that don't need their own pool.
+var defaultUserAgentRotator = newUserAgentRotator(fallbackUserAgents)
+
+// newUserAgentRotator builds a rotator over agents. If agents is empty the
+// rotator falls back to fallbackUserAgents.
+func newUserAgentRotator(agents []string) *userAgentRotator {
+	r := &userAgentRotator{}
+	r.setAgents(agents)
+	return r
+}
+
+// setAgents atomically replaces the rotator's pool. A nil or empty agents
+// leaves the existing pool in place rather than emptying it.
+func (r *userAgentRotator) setAgents(agents []string) {
+	if len(agents) == 0 {
+		if r.agents.Load() == nil {
+			r.agents.Store(&fallbackUserAgents)
+		}
+		return
+	}
+	cp := append([]string(nil), agents...)
+	r.agents.Store(&cp)
+}
+
+// Next returns the next User-Agent in the pool.
+func (r *userAgentRotator) Next() string {
+	agents := *r.agents.Load()
+	i := r.next.Add(1) - 1
+	return agents[i%uint64(len(agents))]
+}
+
+// UserAgentSource fetches an up-to-date list of browser User-Agent
+// strings, e.g. from a remote UA-database API. This package ships no
+// default source so it never makes an unsolicited network call; callers
+// that want live refreshing supply their own.
+type UserAgentSource func(ctx context.Context) ([]string, error)
+
+// userAgentRefreshInterval is how often StartUserAgentRefresher calls its
+// source to check for an updated UA pool. It's a var, not a const, so
+// tests can shrink it instead of waiting a full day for the first tick.
+var userAgentRefreshInterval = 24 * time.Hour
+
+// StartUserAgentRefresher launches a background goroutine that periodically
+// calls source and swaps its result into rotator's pool, so long-running
+// processes don't get stuck rotating through the same UAs as they age out
+// of common use. It returns immediately; the goroutine stops when ctx is
+// done. A failed or empty fetch just keeps the previous pool.
+//
+// Nothing in this tree calls StartUserAgentRefresher yet — it's the
+// extension point a caller with a real UA-database source should use.
+func StartUserAgentRefresher(ctx context.Context, rotator *userAgentRotator, source UserAgentSource) {
+	go func() {
+		ticker := time.NewTicker(userAgentRefreshInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				agents, err := source(ctx)
+				if err != nil || len(agents) == 0 {
+					continue
+				}
+				rotator.setAgents(agents)
+			}
+		}
+	}()
+}
@@ -0,0 +1,90 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+var errBoom = errors.New("boom")
+
+func TestUserAgentRotatorNextCyclesThroughPool(t *testing.T) {
+	agents := []string{"ua-1", "ua-2", "ua-3"}
+	r := newUserAgentRotator(agents)
+
+	for i, want := range append(agents, agents...) {
+		if got := r.Next(); got != want {
+			t.Errorf("call %d: Next() = %q, want %q", i, got, want)
+		}
+	}
+}
+
+func TestNewUserAgentRotatorFallsBackWhenEmpty(t *testing.T) {
+	r := newUserAgentRotator(nil)
+	if got := r.Next(); got != fallbackUserAgents[0] {
+		t.Errorf("Next() = %q, want first fallback UA %q", got, fallbackUserAgents[0])
+	}
+}
+
+func TestUserAgentRotatorSetAgentsSwapsPool(t *testing.T) {
+	r := newUserAgentRotator([]string{"old-1", "old-2"})
+	r.setAgents([]string{"new-1"})
+
+	if got := r.Next(); got != "new-1" {
+		t.Errorf("Next() after setAgents = %q, want %q", got, "new-1")
+	}
+}
+
+func TestUserAgentRotatorSetAgentsIgnoresEmpty(t *testing.T) {
+	r := newUserAgentRotator([]string{"only-1"})
+	r.setAgents(nil)
+
+	if got := r.Next(); got != "only-1" {
+		t.Errorf("Next() after setAgents(nil) = %q, want unchanged pool %q", got, "only-1")
+	}
+}
+
+func TestStartUserAgentRefresherSwapsPoolFromSource(t *testing.T) {
+	oldInterval := userAgentRefreshInterval
+	userAgentRefreshInterval = time.Millisecond
+	defer func() { userAgentRefreshInterval = oldInterval }()
+
+	r := newUserAgentRotator([]string{"old-1"})
+	source := func(ctx context.Context) ([]string, error) {
+		return []string{"refreshed-1"}, nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	StartUserAgentRefresher(ctx, r, source)
+
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if r.Next() == "refreshed-1" {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Errorf("Next() never reflected the refreshed pool, got %q", r.Next())
+}
+
+func TestStartUserAgentRefresherKeepsPoolOnError(t *testing.T) {
+	oldInterval := userAgentRefreshInterval
+	userAgentRefreshInterval = time.Millisecond
+	defer func() { userAgentRefreshInterval = oldInterval }()
+
+	r := newUserAgentRotator([]string{"keep-1"})
+	source := func(ctx context.Context) ([]string, error) {
+		return nil, errBoom
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	StartUserAgentRefresher(ctx, r, source)
+
+	time.Sleep(20 * time.Millisecond)
+	if got := r.Next(); got != "keep-1" {
+		t.Errorf("Next() = %q, want unchanged pool %q after a failing source", got, "keep-1")
+	}
+}
@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"cmp"
 	"context"
 	"fmt"
 	"os"
@@ -10,7 +9,11 @@ import (
 
 	"charm.land/lipgloss/v2"
 	"github.com/charmbracelet/crush/internal/config"
-	"github.com/charmbracelet/crush/internal/oauth/claude"
+	"github.com/charmbracelet/crush/internal/oauth"
+	_ "github.com/charmbracelet/crush/internal/oauth/claude"
+	_ "github.com/charmbracelet/crush/internal/oauth/gemini"
+	_ "github.com/charmbracelet/crush/internal/oauth/githubcopilot"
+	_ "github.com/charmbracelet/crush/internal/oauth/openai"
 	"github.com/spf13/cobra"
 )
 
@@ -18,18 +21,15 @@ var loginCmd = &cobra.Command{
 	Aliases: []string{"auth"},
 	Use:     "login [platform]",
 	Short:   "Login Crush to a platform",
-	Long: `Login Crush to a specified platform.
+	Long: fmt.Sprintf(`Login Crush to a specified platform.
 The platform should be provided as an argument.
-Available platforms are: claude.`,
+Available platforms are: %s.`, strings.Join(oauth.Names(), ", ")),
 	Example: `
 # Authenticate with Claude Code Max
 crush login claude
   `,
-	ValidArgs: []cobra.Completion{
-		"claude",
-		"anthropic",
-	},
-	Args: cobra.ExactArgs(1),
+	ValidArgs: validLoginArgs(),
+	Args:      cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if len(args) > 1 {
 			return fmt.Errorf("wrong number of arguments")
@@ -44,16 +44,36 @@ crush login claude
 		}
 		defer app.Shutdown()
 
-		switch args[0] {
-		case "anthropic", "claude":
-			return loginClaude()
-		default:
+		platform := args[0]
+		if platform == "anthropic" {
+			platform = "claude"
+		}
+
+		flow, err := oauth.Get(platform)
+		if err != nil {
 			return fmt.Errorf("unknown platform: %s", args[0])
 		}
+		return runLogin(flow)
 	},
 }
 
-func loginClaude() error {
+// validLoginArgs returns the registered oauth platform names plus the
+// legacy "anthropic" alias for "claude".
+func validLoginArgs() []cobra.Completion {
+	names := oauth.Names()
+	args := make([]cobra.Completion, 0, len(names)+1)
+	for _, name := range names {
+		args = append(args, cobra.Completion(name))
+	}
+	return append(args, "anthropic")
+}
+
+// runLogin drives flow's authorize/exchange steps and persists the
+// resulting token under flow.ConfigField(). When flow supports a loopback
+// callback and we're not in a remote session, the browser is opened
+// automatically and the code is captured without the user copy-pasting
+// anything; otherwise it falls back to the manual paste flow.
+func runLogin(flow oauth.Flow) error {
 	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, os.Kill)
 	go func() {
 		<-ctx.Done()
@@ -61,48 +81,99 @@ func loginClaude() error {
 		os.Exit(1)
 	}()
 
-	verifier, challenge, err := claude.GetChallenge()
+	var cb *oauth.Callback
+	if flow.SupportsLoopback() && !oauth.IsRemoteSession() {
+		var err error
+		cb, err = oauth.ListenForCallback()
+		if err != nil {
+			fmt.Printf("Couldn't start local callback server (%s), falling back to manual paste.\n", err)
+		}
+	}
+
+	var redirectURI string
+	if cb != nil {
+		redirectURI = cb.URL
+	}
+
+	authorizeURL, verifier, state, err := flow.Authorize(ctx, redirectURI)
 	if err != nil {
 		return err
 	}
-	url, err := claude.AuthorizeURL(verifier, challenge)
+
+	code, err := obtainCode(ctx, flow, cb, authorizeURL, state)
 	if err != nil {
 		return err
 	}
-	fmt.Println("Open the following URL and follow the instructions to authenticate with Claude Code Max:")
-	fmt.Println()
-	fmt.Println(lipgloss.NewStyle().Hyperlink(url, "id=claude").Render(url))
+
 	fmt.Println()
-	fmt.Println("Press enter to continue...")
-	if _, err := fmt.Scanln(); err != nil {
+	fmt.Println("Exchanging authorization code...")
+	token, err := flow.Exchange(ctx, code, verifier, redirectURI)
+	if err != nil {
+		return err
+	}
+
+	cfg := config.Get()
+	if err := cfg.SetConfigField(flow.ConfigField()+".api_key", token.AccessToken); err != nil {
+		return err
+	}
+	if err := cfg.SetConfigField(flow.ConfigField()+".oauth", token); err != nil {
 		return err
 	}
 
-	fmt.Println("Now paste and code from Anthropic and press enter...")
 	fmt.Println()
-	fmt.Print("> ")
-	var code string
-	for code == "" {
-		_, _ = fmt.Scanln(&code)
-		code = strings.TrimSpace(code)
+	fmt.Printf("You're now authenticated with %s!\n", flow.Name())
+	return nil
+}
+
+// obtainCode gets the authorization code back from the provider, either
+// via the loopback callback server or, if that isn't usable, by asking
+// the user to paste it. cb is nil when the loopback server couldn't be
+// started or isn't supported/usable, in which case it falls back to the
+// manual paste flow.
+func obtainCode(ctx context.Context, flow oauth.Flow, cb *oauth.Callback, authorizeURL, state string) (string, error) {
+	if cb == nil {
+		return pasteCode(flow, authorizeURL)
 	}
 
+	fmt.Printf("Opening your browser to authenticate with %s...\n", flow.Name())
 	fmt.Println()
-	fmt.Println("Exchanging authorization code...")
-	token, err := claude.ExchangeToken(ctx, code, verifier)
+	fmt.Println(lipgloss.NewStyle().Hyperlink(authorizeURL, "id="+flow.Name()).Render(authorizeURL))
+	fmt.Println()
+	if err := oauth.OpenBrowser(authorizeURL); err != nil {
+		fmt.Println("Couldn't open a browser automatically; open the URL above manually.")
+	}
+
+	result, err := cb.WaitForCallback(ctx, oauth.DefaultCallbackTimeout)
 	if err != nil {
-		return err
+		fmt.Printf("Local callback failed (%s), falling back to manual paste.\n", err)
+		return pasteCode(flow, authorizeURL)
+	}
+	if result.State != state {
+		return "", fmt.Errorf("oauth state mismatch, possible CSRF attempt")
 	}
 
-	cfg := config.Get()
-	if err := cmp.Or(
-		cfg.SetConfigField("providers.anthropic.api_key", token.AccessToken),
-		cfg.SetConfigField("providers.anthropic.oauth", token),
-	); err != nil {
-		return err
+	return result.Code, nil
+}
+
+// pasteCode asks the user to open authorizeURL themselves and paste back
+// the code the provider gives them.
+func pasteCode(flow oauth.Flow, authorizeURL string) (string, error) {
+	fmt.Printf("Open the following URL and follow the instructions to authenticate with %s:\n", flow.Name())
+	fmt.Println()
+	fmt.Println(lipgloss.NewStyle().Hyperlink(authorizeURL, "id="+flow.Name()).Render(authorizeURL))
+	fmt.Println()
+	fmt.Println("Press enter to continue...")
+	if _, err := fmt.Scanln(); err != nil {
+		return "", err
 	}
 
+	fmt.Printf("Now paste the code from %s and press enter...\n", flow.Name())
 	fmt.Println()
-	fmt.Println("You're now authenticated with Claude Code Max!")
-	return nil
+	fmt.Print("> ")
+	var code string
+	for code == "" {
+		_, _ = fmt.Scanln(&code)
+		code = strings.TrimSpace(code)
+	}
+	return code, nil
 }
@@ -0,0 +1,144 @@
+package oauth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"runtime"
+	"time"
+)
+
+// DefaultCallbackTimeout bounds how long WaitForCallback blocks before the
+// caller should fall back to the manual copy-paste flow.
+const DefaultCallbackTimeout = 5 * time.Minute
+
+// CallbackResult carries the code/state pair extracted from an OAuth
+// redirect, or the error the provider reported instead.
+type CallbackResult struct {
+	Code  string
+	State string
+	Err   error
+}
+
+// Callback is a loopback HTTP server waiting for a single OAuth redirect.
+type Callback struct {
+	URL    string
+	result chan CallbackResult
+	server *http.Server
+}
+
+// ListenForCallback binds an HTTP server to 127.0.0.1 on an ephemeral port
+// that serves the OAuth redirect at /auth/callback, extracts code and
+// state from the query string, renders a success page, and delivers the
+// result via WaitForCallback. The bound port is only known once the
+// listener is up, so the resulting Callback.URL must be passed to
+// Flow.Authorize as its redirectURI — loopback redirects are registered
+// per RFC 8252 as "http://127.0.0.1:*/..." precisely so the port can vary
+// per login attempt.
+func ListenForCallback() (*Callback, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("failed to bind loopback callback server: %w", err)
+	}
+	port := listener.Addr().(*net.TCPAddr).Port
+
+	cb := &Callback{
+		URL:    fmt.Sprintf("http://127.0.0.1:%d/auth/callback", port),
+		result: make(chan CallbackResult, 1),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/auth/callback", cb.handle)
+	cb.server = &http.Server{Handler: mux}
+
+	go func() {
+		if err := cb.server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			cb.result <- CallbackResult{Err: fmt.Errorf("callback server error: %w", err)}
+		}
+	}()
+
+	return cb, nil
+}
+
+func (cb *Callback) handle(w http.ResponseWriter, r *http.Request) {
+	result := CallbackResult{
+		Code:  r.URL.Query().Get("code"),
+		State: r.URL.Query().Get("state"),
+	}
+	if errParam := r.URL.Query().Get("error"); errParam != "" {
+		result.Err = fmt.Errorf("authorization denied: %s", errParam)
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	fmt.Fprint(w, callbackSuccessPage)
+
+	select {
+	case cb.result <- result:
+	default:
+	}
+}
+
+// WaitForCallback blocks until the browser hits the callback URL or
+// timeout elapses, then shuts the server down.
+func (cb *Callback) WaitForCallback(ctx context.Context, timeout time.Duration) (CallbackResult, error) {
+	defer cb.close()
+
+	select {
+	case result := <-cb.result:
+		return result, result.Err
+	case <-time.After(timeout):
+		return CallbackResult{}, fmt.Errorf("timed out after %s waiting for the browser to redirect back", timeout)
+	case <-ctx.Done():
+		return CallbackResult{}, ctx.Err()
+	}
+}
+
+func (cb *Callback) close() {
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	_ = cb.server.Shutdown(shutdownCtx)
+}
+
+const callbackSuccessPage = `<!doctype html>
+<html>
+<head><title>Crush</title></head>
+<body style="font-family: -apple-system, sans-serif; text-align: center; padding-top: 4rem;">
+<h1>You're all set</h1>
+<p>You can close this tab and return to the terminal.</p>
+</body>
+</html>`
+
+// RandomState generates a cryptographically random CSRF state parameter.
+func RandomState() (string, error) {
+	raw := make([]byte, 24)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("failed to generate state: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// IsRemoteSession reports whether this process is likely running over
+// SSH, where a local browser can't reach our loopback callback server.
+func IsRemoteSession() bool {
+	return os.Getenv("SSH_CONNECTION") != "" || os.Getenv("SSH_CLIENT") != ""
+}
+
+// OpenBrowser attempts to open url in the user's default browser across
+// platforms. Callers should fall back to printing the URL if it errors.
+func OpenBrowser(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	return cmd.Start()
+}
@@ -0,0 +1,167 @@
+// Package claude implements the oauth.Flow for Claude Code Max / Anthropic
+// Console login.
+package claude
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/crush/internal/oauth"
+)
+
+const (
+	clientID     = "9d1c250a-e61b-44d9-88ed-5944d1962f5e"
+	authorizeURL = "https://claude.ai/oauth/authorize"
+	tokenURL     = "https://console.anthropic.com/v1/oauth/token"
+	scopes       = "org:create_api_key user:profile user:inference"
+
+	// defaultRedirectURI is used for the manual copy-paste flow, where no
+	// loopback server is listening. The loopback flow instead passes the
+	// callback server's actual (ephemeral-port) URL to Authorize.
+	defaultRedirectURI = "http://localhost:1454/auth/callback"
+)
+
+func init() {
+	oauth.Register(&flow{client: http.DefaultClient})
+}
+
+// flow is the oauth.Flow implementation for Anthropic.
+type flow struct {
+	client *http.Client
+}
+
+func (f *flow) Name() string           { return "claude" }
+func (f *flow) ConfigField() string    { return "providers.anthropic" }
+func (f *flow) SupportsLoopback() bool { return true }
+
+// Authorize generates PKCE parameters and a random CSRF state, and returns
+// the authorize URL. redirectURI overrides the fixed fallback redirect
+// when the caller started a loopback callback server; pass "" to use it.
+func (f *flow) Authorize(ctx context.Context, redirectURI string) (string, string, string, error) {
+	if redirectURI == "" {
+		redirectURI = defaultRedirectURI
+	}
+	verifier, challenge, err := GetChallenge()
+	if err != nil {
+		return "", "", "", err
+	}
+	state, err := oauth.RandomState()
+	if err != nil {
+		return "", "", "", err
+	}
+	authURL, err := AuthorizeURL(redirectURI, verifier, challenge, state)
+	if err != nil {
+		return "", "", "", err
+	}
+	return authURL, verifier, state, nil
+}
+
+// Exchange trades an authorization code for a Token.
+func (f *flow) Exchange(ctx context.Context, code, verifier, redirectURI string) (oauth.Token, error) {
+	if redirectURI == "" {
+		redirectURI = defaultRedirectURI
+	}
+	tok, err := ExchangeToken(ctx, code, verifier, redirectURI)
+	if err != nil {
+		return oauth.Token{}, err
+	}
+	return tok, nil
+}
+
+// Refresh exchanges a refresh token for a new Token.
+func (f *flow) Refresh(ctx context.Context, refreshToken string) (oauth.Token, error) {
+	return f.doTokenRequest(ctx, oauth.RefreshBody(clientID, refreshToken))
+}
+
+func (f *flow) doTokenRequest(ctx context.Context, body url.Values) (oauth.Token, error) {
+	return oauth.TokenRequest(ctx, f.client, tokenURL, "claude", body)
+}
+
+// GetChallenge generates a PKCE code verifier and its S256 challenge.
+func GetChallenge() (verifier, challenge string, err error) {
+	return oauth.GeneratePKCE()
+}
+
+// AuthorizeURL builds the URL the user opens in a browser to authenticate.
+func AuthorizeURL(redirectURI, verifier, challenge, state string) (string, error) {
+	u, err := url.Parse(authorizeURL)
+	if err != nil {
+		return "", err
+	}
+
+	q := u.Query()
+	q.Set("code", "true")
+	q.Set("client_id", clientID)
+	q.Set("response_type", "code")
+	q.Set("redirect_uri", redirectURI)
+	q.Set("scope", scopes)
+	q.Set("code_challenge", challenge)
+	q.Set("code_challenge_method", "S256")
+	q.Set("state", state)
+	u.RawQuery = q.Encode()
+
+	return u.String(), nil
+}
+
+// ExchangeToken trades an authorization code for an access/refresh token
+// pair. code may come back from the manual-paste flow as "code#state"; the
+// state suffix, if present, is stripped before the request is sent.
+// redirectURI must match the one used to obtain code.
+func ExchangeToken(ctx context.Context, code, verifier, redirectURI string) (oauth.Token, error) {
+	code, _, _ = strings.Cut(code, "#")
+
+	body := map[string]string{
+		"code":          code,
+		"grant_type":    "authorization_code",
+		"client_id":     clientID,
+		"redirect_uri":  redirectURI,
+		"code_verifier": verifier,
+	}
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return oauth.Token{}, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, strings.NewReader(string(payload)))
+	if err != nil {
+		return oauth.Token{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return oauth.Token{}, err
+	}
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return oauth.Token{}, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return oauth.Token{}, fmt.Errorf("token exchange failed with status %d: %s", resp.StatusCode, raw)
+	}
+
+	var tokenResp struct {
+		AccessToken  string `json:"access_token"`
+		RefreshToken string `json:"refresh_token"`
+		TokenType    string `json:"token_type"`
+		ExpiresIn    int64  `json:"expires_in"`
+	}
+	if err := json.Unmarshal(raw, &tokenResp); err != nil {
+		return oauth.Token{}, fmt.Errorf("failed to parse token response: %w", err)
+	}
+
+	return oauth.Token{
+		AccessToken:  tokenResp.AccessToken,
+		RefreshToken: tokenResp.RefreshToken,
+		TokenType:    tokenResp.TokenType,
+		ExpiresAt:    time.Now().Add(time.Duration(tokenResp.ExpiresIn) * time.Second),
+	}, nil
+}
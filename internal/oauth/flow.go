@@ -0,0 +1,105 @@
+// Package oauth provides a provider-agnostic OAuth login flow abstraction
+// so new platforms (OpenAI, Gemini, GitHub Copilot, ...) can be added
+// without touching the login command.
+package oauth
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Token is the credential set a Flow exchanges an authorization code for.
+// It is stored verbatim under providers.<name>.oauth so it round-trips
+// through config.SetConfigField.
+type Token struct {
+	AccessToken  string    `json:"access_token"`
+	RefreshToken string    `json:"refresh_token,omitempty"`
+	TokenType    string    `json:"token_type,omitempty"`
+	ExpiresAt    time.Time `json:"expires_at,omitempty"`
+}
+
+// Expired reports whether the token is expired or within skew of expiring.
+func (t Token) Expired(skew time.Duration) bool {
+	if t.ExpiresAt.IsZero() {
+		return false
+	}
+	return time.Now().Add(skew).After(t.ExpiresAt)
+}
+
+// Flow implements the OAuth login process for a single platform: building
+// the authorize URL, exchanging the authorization code for a token, and
+// refreshing that token before it expires.
+type Flow interface {
+	// Name is the platform name used on the `login` command line and as
+	// the registry key. The config key is ConfigField, which need not
+	// match it (the "claude" flow writes to providers.anthropic).
+	Name() string
+
+	// Authorize generates PKCE parameters (and a random CSRF state,
+	// independent of the verifier) and returns the URL the user should
+	// open in a browser to authenticate. redirectURI overrides the
+	// flow's default redirect — callers that started a loopback
+	// callback server pass its actual (ephemeral-port) URL here so it
+	// matches what the server is listening on; pass "" to use the
+	// flow's fixed fallback redirect for the manual paste flow.
+	Authorize(ctx context.Context, redirectURI string) (authorizeURL, verifier, state string, err error)
+
+	// Exchange trades an authorization code (and the verifier returned
+	// by Authorize) for a Token. redirectURI must be the exact value
+	// passed to Authorize, since providers validate it against the one
+	// used for the original authorization request.
+	Exchange(ctx context.Context, code, verifier, redirectURI string) (Token, error)
+
+	// Refresh exchanges a refresh token for a new Token. Flows that don't
+	// support refresh tokens should return an error.
+	Refresh(ctx context.Context, refreshToken string) (Token, error)
+
+	// ConfigField returns the config key the resulting Token should be
+	// written to, e.g. "providers.anthropic".
+	ConfigField() string
+
+	// SupportsLoopback reports whether this flow's redirect can be
+	// pointed at an arbitrary local callback server. false means it must
+	// use the manual copy-paste flow with the flow's fixed redirect.
+	SupportsLoopback() bool
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]Flow{}
+)
+
+// Register adds a Flow to the registry under flow.Name(). It is intended
+// to be called from provider packages' init functions.
+func Register(flow Flow) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[flow.Name()] = flow
+}
+
+// Get looks up a registered Flow by platform name.
+func Get(name string) (Flow, error) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	flow, ok := registry[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown oauth platform: %s", name)
+	}
+	return flow, nil
+}
+
+// Names returns the sorted list of registered platform names, used for the
+// login command's ValidArgs and help text.
+func Names() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
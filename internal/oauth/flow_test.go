@@ -0,0 +1,48 @@
+package oauth
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTokenExpired(t *testing.T) {
+	tests := []struct {
+		name string
+		tok  Token
+		skew time.Duration
+		want bool
+	}{
+		{
+			name: "zero ExpiresAt never expires",
+			tok:  Token{},
+			skew: 0,
+			want: false,
+		},
+		{
+			name: "future expiry is not expired",
+			tok:  Token{ExpiresAt: time.Now().Add(time.Hour)},
+			skew: 0,
+			want: false,
+		},
+		{
+			name: "past expiry is expired",
+			tok:  Token{ExpiresAt: time.Now().Add(-time.Hour)},
+			skew: 0,
+			want: true,
+		},
+		{
+			name: "skew pulls a near-future expiry into expired",
+			tok:  Token{ExpiresAt: time.Now().Add(30 * time.Second)},
+			skew: time.Minute,
+			want: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.tok.Expired(tt.skew); got != tt.want {
+				t.Errorf("Expired(%v) = %v, want %v", tt.skew, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+// Package githubcopilot implements the oauth.Flow for GitHub Copilot
+// login. GitHub's device/OAuth app flow has no PKCE or refresh token; the
+// access token it issues is long-lived, so Refresh always errors.
+package githubcopilot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/charmbracelet/crush/internal/oauth"
+)
+
+const (
+	clientID     = "Iv1.b507a08c87ecfe98"
+	authorizeURL = "https://github.com/login/oauth/authorize"
+	tokenURL     = "https://github.com/login/oauth/access_token"
+	scopes       = "read:user copilot"
+
+	// defaultRedirectURI is used for the manual copy-paste flow, where no
+	// loopback server is listening. The loopback flow instead passes the
+	// callback server's actual (ephemeral-port) URL to Authorize.
+	defaultRedirectURI = "http://localhost:1457/auth/callback"
+)
+
+func init() {
+	oauth.Register(&flow{client: http.DefaultClient})
+}
+
+type flow struct {
+	client *http.Client
+}
+
+func (f *flow) Name() string           { return "github-copilot" }
+func (f *flow) ConfigField() string    { return "providers.github-copilot" }
+func (f *flow) SupportsLoopback() bool { return true }
+
+// Authorize builds the GitHub authorize URL. GitHub's OAuth app flow
+// doesn't use PKCE, so the returned verifier is only used as the CSRF
+// state parameter on Exchange. redirectURI overrides the fixed fallback
+// redirect when the caller started a loopback callback server; pass ""
+// to use it.
+func (f *flow) Authorize(ctx context.Context, redirectURI string) (string, string, string, error) {
+	if redirectURI == "" {
+		redirectURI = defaultRedirectURI
+	}
+
+	state, err := oauth.RandomState()
+	if err != nil {
+		return "", "", "", err
+	}
+
+	u, err := url.Parse(authorizeURL)
+	if err != nil {
+		return "", "", "", err
+	}
+	q := u.Query()
+	q.Set("client_id", clientID)
+	q.Set("redirect_uri", redirectURI)
+	q.Set("scope", scopes)
+	q.Set("state", state)
+	u.RawQuery = q.Encode()
+
+	return u.String(), state, state, nil
+}
+
+func (f *flow) Exchange(ctx context.Context, code, verifier, redirectURI string) (oauth.Token, error) {
+	body := url.Values{}
+	body.Set("client_id", clientID)
+	body.Set("code", code)
+	body.Set("state", verifier)
+
+	raw, err := oauth.PostForm(ctx, f.client, tokenURL, "github copilot", body, map[string]string{"Accept": "application/json"})
+	if err != nil {
+		return oauth.Token{}, err
+	}
+
+	var payload struct {
+		AccessToken string `json:"access_token"`
+		TokenType   string `json:"token_type"`
+	}
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		return oauth.Token{}, fmt.Errorf("failed to parse github copilot token response: %w", err)
+	}
+
+	return oauth.Token{
+		AccessToken: payload.AccessToken,
+		TokenType:   payload.TokenType,
+		// GitHub's OAuth app tokens don't expire on a fixed schedule.
+		ExpiresAt: time.Time{},
+	}, nil
+}
+
+// Refresh is unsupported: GitHub's OAuth app flow issues long-lived
+// tokens with no refresh token.
+func (f *flow) Refresh(ctx context.Context, refreshToken string) (oauth.Token, error) {
+	return oauth.Token{}, fmt.Errorf("github copilot tokens do not support refresh")
+}
@@ -0,0 +1,89 @@
+// Package openai implements the oauth.Flow for ChatGPT / OpenAI platform
+// login.
+package openai
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/charmbracelet/crush/internal/oauth"
+)
+
+const (
+	clientID     = "app_EMoamEEZ73f0CkXaXp7hrann"
+	authorizeURL = "https://auth.openai.com/oauth/authorize"
+	tokenURL     = "https://auth.openai.com/oauth/token"
+	scopes       = "openid profile email offline_access"
+
+	// defaultRedirectURI is used for the manual copy-paste flow, where no
+	// loopback server is listening. The loopback flow instead passes the
+	// callback server's actual (ephemeral-port) URL to Authorize.
+	defaultRedirectURI = "http://localhost:1455/auth/callback"
+)
+
+func init() {
+	oauth.Register(&flow{client: http.DefaultClient})
+}
+
+type flow struct {
+	client *http.Client
+}
+
+func (f *flow) Name() string           { return "openai" }
+func (f *flow) ConfigField() string    { return "providers.openai" }
+func (f *flow) SupportsLoopback() bool { return true }
+
+// Authorize generates PKCE parameters and a random CSRF state, and returns
+// the authorize URL. redirectURI overrides the fixed fallback redirect
+// when the caller started a loopback callback server; pass "" to use it.
+func (f *flow) Authorize(ctx context.Context, redirectURI string) (string, string, string, error) {
+	if redirectURI == "" {
+		redirectURI = defaultRedirectURI
+	}
+
+	verifier, challenge, err := oauth.GeneratePKCE()
+	if err != nil {
+		return "", "", "", err
+	}
+
+	state, err := oauth.RandomState()
+	if err != nil {
+		return "", "", "", err
+	}
+
+	u, err := url.Parse(authorizeURL)
+	if err != nil {
+		return "", "", "", err
+	}
+	q := u.Query()
+	q.Set("client_id", clientID)
+	q.Set("response_type", "code")
+	q.Set("redirect_uri", redirectURI)
+	q.Set("scope", scopes)
+	q.Set("code_challenge", challenge)
+	q.Set("code_challenge_method", "S256")
+	q.Set("state", state)
+	u.RawQuery = q.Encode()
+
+	return u.String(), verifier, state, nil
+}
+
+func (f *flow) Exchange(ctx context.Context, code, verifier, redirectURI string) (oauth.Token, error) {
+	if redirectURI == "" {
+		redirectURI = defaultRedirectURI
+	}
+
+	body := url.Values{}
+	body.Set("grant_type", "authorization_code")
+	body.Set("code", code)
+	body.Set("client_id", clientID)
+	body.Set("redirect_uri", redirectURI)
+	body.Set("code_verifier", verifier)
+
+	return oauth.TokenRequest(ctx, f.client, tokenURL, "openai", body)
+}
+
+func (f *flow) Refresh(ctx context.Context, refreshToken string) (oauth.Token, error) {
+	return oauth.TokenRequest(ctx, f.client, tokenURL, "openai", oauth.RefreshBody(clientID, refreshToken))
+}
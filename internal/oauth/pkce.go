@@ -0,0 +1,99 @@
+package oauth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// GeneratePKCE creates a PKCE code verifier and its S256 challenge, per
+// RFC 7636. Every Flow in this tree that uses the authorization-code grant
+// with PKCE calls this instead of rolling its own.
+func GeneratePKCE() (verifier, challenge string, err error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", "", fmt.Errorf("failed to generate verifier: %w", err)
+	}
+	verifier = base64.RawURLEncoding.EncodeToString(raw)
+
+	sum := sha256.Sum256([]byte(verifier))
+	challenge = base64.RawURLEncoding.EncodeToString(sum[:])
+
+	return verifier, challenge, nil
+}
+
+// RefreshBody builds the standard refresh_token grant body shared by every
+// PKCE flow's Refresh method.
+func RefreshBody(clientID, refreshToken string) url.Values {
+	body := url.Values{}
+	body.Set("grant_type", "refresh_token")
+	body.Set("refresh_token", refreshToken)
+	body.Set("client_id", clientID)
+	return body
+}
+
+// PostForm posts body to tokenURL as application/x-www-form-urlencoded,
+// sets any extraHeaders, and returns the raw response body after checking
+// for a non-200 status. name qualifies the error message (e.g. "openai",
+// "github copilot") so failures are traceable back to the provider.
+func PostForm(ctx context.Context, client *http.Client, tokenURL, name string, body url.Values, extraHeaders map[string]string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, strings.NewReader(body.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	for k, v := range extraHeaders {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s token request failed with status %d: %s", name, resp.StatusCode, raw)
+	}
+	return raw, nil
+}
+
+// TokenRequest posts body to tokenURL and decodes the standard
+// access_token/refresh_token/token_type/expires_in response shape shared
+// by every provider in this package except GitHub Copilot, which has its
+// own response shape and calls PostForm directly.
+func TokenRequest(ctx context.Context, client *http.Client, tokenURL, name string, body url.Values) (Token, error) {
+	raw, err := PostForm(ctx, client, tokenURL, name, body, nil)
+	if err != nil {
+		return Token{}, err
+	}
+
+	var payload struct {
+		AccessToken  string `json:"access_token"`
+		RefreshToken string `json:"refresh_token"`
+		TokenType    string `json:"token_type"`
+		ExpiresIn    int64  `json:"expires_in"`
+	}
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		return Token{}, fmt.Errorf("failed to parse %s token response: %w", name, err)
+	}
+
+	return Token{
+		AccessToken:  payload.AccessToken,
+		RefreshToken: payload.RefreshToken,
+		TokenType:    payload.TokenType,
+		ExpiresAt:    time.Now().Add(time.Duration(payload.ExpiresIn) * time.Second),
+	}, nil
+}
@@ -0,0 +1,75 @@
+package oauth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestGeneratePKCEChallengeMatchesVerifier(t *testing.T) {
+	verifier, challenge, err := GeneratePKCE()
+	if err != nil {
+		t.Fatalf("GeneratePKCE() error = %v", err)
+	}
+	if verifier == "" || challenge == "" {
+		t.Fatalf("GeneratePKCE() = %q, %q, want non-empty", verifier, challenge)
+	}
+
+	sum := sha256.Sum256([]byte(verifier))
+	want := base64.RawURLEncoding.EncodeToString(sum[:])
+	if challenge != want {
+		t.Errorf("challenge = %q, want S256(verifier) = %q", challenge, want)
+	}
+}
+
+func TestRefreshBody(t *testing.T) {
+	body := RefreshBody("client-123", "refresh-456")
+	if got := body.Get("grant_type"); got != "refresh_token" {
+		t.Errorf("grant_type = %q, want refresh_token", got)
+	}
+	if got := body.Get("refresh_token"); got != "refresh-456" {
+		t.Errorf("refresh_token = %q, want refresh-456", got)
+	}
+	if got := body.Get("client_id"); got != "client-123" {
+		t.Errorf("client_id = %q, want client-123", got)
+	}
+}
+
+func TestTokenRequestDecodesPayload(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"access_token":"at","refresh_token":"rt","token_type":"Bearer","expires_in":3600}`))
+	}))
+	defer srv.Close()
+
+	tok, err := TokenRequest(context.Background(), srv.Client(), srv.URL, "test", url.Values{})
+	if err != nil {
+		t.Fatalf("TokenRequest() error = %v", err)
+	}
+	if tok.AccessToken != "at" || tok.RefreshToken != "rt" || tok.TokenType != "Bearer" {
+		t.Errorf("TokenRequest() = %+v, want access/refresh/type at/rt/Bearer", tok)
+	}
+	if tok.ExpiresAt.IsZero() {
+		t.Errorf("TokenRequest() ExpiresAt is zero, want set from expires_in")
+	}
+}
+
+func TestTokenRequestNonOKStatusNamesProvider(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`invalid_grant`))
+	}))
+	defer srv.Close()
+
+	_, err := TokenRequest(context.Background(), srv.Client(), srv.URL, "acme", url.Values{})
+	if err == nil {
+		t.Fatal("TokenRequest() error = nil, want non-nil for a 400 response")
+	}
+	if got := err.Error(); !strings.Contains(got, "acme token request failed") {
+		t.Errorf("error = %q, want it to name the provider", got)
+	}
+}
@@ -0,0 +1,84 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/charmbracelet/crush/internal/config"
+)
+
+// refreshCheckInterval is how often stored tokens are checked for
+// upcoming expiry.
+const refreshCheckInterval = 5 * time.Minute
+
+// refreshSkew is how far ahead of expiry a token is proactively refreshed.
+const refreshSkew = 10 * time.Minute
+
+// StartRefresher launches a background goroutine that watches every
+// registered provider's stored OAuth token and refreshes it shortly
+// before it expires, persisting the result via cfg.SetConfigField. It
+// returns immediately; the goroutine stops when ctx is done.
+func StartRefresher(ctx context.Context, cfg *config.Config) {
+	go func() {
+		ticker := time.NewTicker(refreshCheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				refreshAll(ctx, cfg)
+			}
+		}
+	}()
+}
+
+func refreshAll(ctx context.Context, cfg *config.Config) {
+	for _, name := range Names() {
+		flow, err := Get(name)
+		if err != nil {
+			continue
+		}
+
+		token, ok := loadToken(cfg, flow.ConfigField())
+		if !ok || token.RefreshToken == "" || !token.Expired(refreshSkew) {
+			continue
+		}
+
+		refreshed, err := flow.Refresh(ctx, token.RefreshToken)
+		if err != nil {
+			slog.Warn("oauth: failed to refresh token", "provider", name, "error", err)
+			continue
+		}
+
+		if err := cfg.SetConfigField(flow.ConfigField()+".api_key", refreshed.AccessToken); err != nil {
+			slog.Warn("oauth: failed to persist refreshed api key", "provider", name, "error", err)
+			continue
+		}
+		if err := cfg.SetConfigField(flow.ConfigField()+".oauth", refreshed); err != nil {
+			slog.Warn("oauth: failed to persist refreshed token", "provider", name, "error", err)
+		}
+	}
+}
+
+// loadToken reads and decodes the Token stored at field+".oauth".
+func loadToken(cfg *config.Config, field string) (Token, bool) {
+	raw, err := cfg.GetConfigField(field + ".oauth")
+	if err != nil || raw == nil {
+		return Token{}, false
+	}
+
+	// Config fields round-trip through JSON, so re-marshal whatever
+	// shape the store gave us back into a Token.
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return Token{}, false
+	}
+	var token Token
+	if err := json.Unmarshal(data, &token); err != nil {
+		return Token{}, false
+	}
+	return token, true
+}
@@ -3,16 +3,29 @@ package util
 import (
 	"context"
 	"errors"
+	"io"
+	"os"
 	"os/exec"
+	"time"
 
 	tea "charm.land/bubbletea/v2"
 	"mvdan.cc/sh/v3/shell"
 )
 
-// ExecShell parses a shell command string and executes it with exec.Command.
-// Uses shell.Fields for proper handling of shell syntax like quotes and
-// arguments while preserving TTY handling for terminal editors.
+// ExecShell parses a shell command string and executes it with exec.Command,
+// enforcing DefaultUserPolicy(). Uses shell.Fields for proper handling of
+// shell syntax like quotes and arguments while preserving TTY handling for
+// terminal editors.
 func ExecShell(ctx context.Context, cmdStr string, callback tea.ExecCallback) tea.Cmd {
+	return ExecShellWithPolicy(ctx, cmdStr, DefaultUserPolicy(), callback)
+}
+
+// ExecShellWithPolicy is like ExecShell but lets the caller choose the
+// ExecPolicy to enforce — agent tool invocations should pass
+// DefaultAgentPolicy() so the command is allowlist-checked, resource
+// limited, and sandboxed, while user-typed commands can keep the lighter
+// DefaultUserPolicy().
+func ExecShellWithPolicy(ctx context.Context, cmdStr string, policy ExecPolicy, callback tea.ExecCallback) tea.Cmd {
 	fields, err := shell.Fields(cmdStr, nil)
 	if err != nil {
 		return ReportError(err)
@@ -21,6 +34,91 @@ func ExecShell(ctx context.Context, cmdStr string, callback tea.ExecCallback) te
 		return ReportError(errors.New("empty command"))
 	}
 
+	if err := policy.Check(cmdStr, fields[0]); err != nil {
+		return ReportError(err)
+	}
+
+	fields = wrapWithLimits(fields, policy.Limits)
+	if policy.Sandbox {
+		fields = wrapWithSandbox(fields)
+	}
+
+	var cancel context.CancelFunc
+	if policy.Limits.WallClock > 0 {
+		ctx, cancel = context.WithTimeout(ctx, policy.Limits.WallClock)
+	}
+
 	cmd := exec.CommandContext(ctx, fields[0], fields[1:]...)
-	return tea.ExecProcess(cmd, callback)
+	exe := &policyExecCommand{cmd: cmd, limits: policy.Limits, cancel: cancel}
+
+	return tea.Exec(exe, auditedCallback(cmdStr, cmd, callback))
+}
+
+// policyExecCommand adapts *exec.Cmd to tea.ExecCommand so we can attach
+// platform resource limits between Start and Wait, which tea.ExecProcess
+// doesn't give callers a chance to do.
+type policyExecCommand struct {
+	cmd    *exec.Cmd
+	limits ResourceLimits
+	cancel context.CancelFunc
+}
+
+func (e *policyExecCommand) SetStdin(r io.Reader)  { e.cmd.Stdin = r }
+func (e *policyExecCommand) SetStdout(w io.Writer) { e.cmd.Stdout = w }
+func (e *policyExecCommand) SetStderr(w io.Writer) { e.cmd.Stderr = w }
+
+func (e *policyExecCommand) Run() error {
+	if e.cancel != nil {
+		defer e.cancel()
+	}
+
+	if err := e.cmd.Start(); err != nil {
+		return err
+	}
+
+	cleanup, err := attachPlatformLimits(e.cmd, e.limits)
+	if err == nil && cleanup != nil {
+		defer cleanup()
+	}
+
+	return e.cmd.Wait()
+}
+
+// auditedCallback wraps callback so every executed command is recorded to
+// the audit log with its exit code, regardless of how it finishes.
+func auditedCallback(cmdStr string, cmd *exec.Cmd, callback tea.ExecCallback) tea.ExecCallback {
+	return func(err error) tea.Msg {
+		// cmd.Dir is only set when the caller wants to run in a directory
+		// other than the process's own; exec.Cmd treats "" as "inherit the
+		// current working directory", so that's what gets recorded too.
+		dir := cmd.Dir
+		if dir == "" {
+			if wd, wdErr := os.Getwd(); wdErr == nil {
+				dir = wd
+			}
+		}
+
+		entry := auditEntry{
+			Time:    time.Now(),
+			Command: cmdStr,
+			Dir:     dir,
+		}
+
+		var exitErr *exec.ExitError
+		switch {
+		case err == nil:
+			entry.ExitCode = 0
+		case errors.As(err, &exitErr):
+			entry.ExitCode = exitErr.ExitCode()
+		default:
+			entry.Error = err.Error()
+			entry.ExitCode = -1
+		}
+		appendAuditLog(entry)
+
+		if callback == nil {
+			return nil
+		}
+		return callback(err)
+	}
 }
@@ -0,0 +1,57 @@
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// auditLogPath is where every executed command is recorded.
+const auditLogPath = ".crush/audit.log"
+
+// auditEntry is one line of the audit log. ExecShell runs commands with
+// the terminal attached directly (for interactive tools like editors), so
+// stdout/stderr aren't captured here to hash.
+type auditEntry struct {
+	Time     time.Time `json:"time"`
+	Command  string    `json:"command"`
+	Dir      string    `json:"dir"`
+	ExitCode int       `json:"exit_code"`
+	Error    string    `json:"error,omitempty"`
+}
+
+var auditMu sync.Mutex
+
+// appendAuditLog records one executed command to ~/.crush/audit.log.
+// Failures to write the audit log are swallowed: a missing audit trail
+// should never be the reason a command fails to run.
+func appendAuditLog(entry auditEntry) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return
+	}
+
+	path := filepath.Join(home, auditLogPath)
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+
+	auditMu.Lock()
+	defer auditMu.Unlock()
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+
+	fmt.Fprintln(f, string(line))
+}
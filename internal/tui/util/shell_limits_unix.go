@@ -0,0 +1,45 @@
+//go:build unix
+
+package util
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// wrapWithLimits rewraps fields so the shell's ulimit builtin applies
+// limits.CPUTime and limits.MaxMemory to the process before it execs.
+// Go's os/exec has no portable way to set rlimits on a child directly, so
+// we reuse the shell that's already parsing the command for us.
+func wrapWithLimits(fields []string, limits ResourceLimits) []string {
+	var ulimits []string
+	if limits.CPUTime > 0 {
+		ulimits = append(ulimits, fmt.Sprintf("ulimit -t %d", int(limits.CPUTime.Seconds())))
+	}
+	if limits.MaxMemory > 0 {
+		ulimits = append(ulimits, fmt.Sprintf("ulimit -v %d", limits.MaxMemory/1024))
+	}
+	if len(ulimits) == 0 {
+		return fields
+	}
+
+	quoted := make([]string, len(fields))
+	for i, f := range fields {
+		quoted[i] = shellQuote(f)
+	}
+
+	script := strings.Join(ulimits, "; ") + fmt.Sprintf("; exec %s", strings.Join(quoted, " "))
+	return []string{"sh", "-c", script}
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// attachPlatformLimits is a no-op on unix: CPU time and memory limits are
+// already applied via the ulimit wrapping in wrapWithLimits before exec,
+// and wall-clock is enforced by the caller's context timeout.
+func attachPlatformLimits(cmd *exec.Cmd, limits ResourceLimits) (cleanup func(), err error) {
+	return nil, nil
+}
@@ -0,0 +1,84 @@
+//go:build windows
+
+package util
+
+import (
+	"fmt"
+	"os/exec"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// wrapWithLimits is a no-op on Windows: limits are applied after the
+// process starts via a Job Object in applyJobObjectLimits, since Windows
+// has no shell-level ulimit equivalent.
+func wrapWithLimits(fields []string, limits ResourceLimits) []string {
+	return fields
+}
+
+// applyJobObjectLimits creates a Job Object with limits.CPUTime and
+// limits.MaxMemory set, and assigns cmd's process to it once started.
+// Closing the Job Object's handle terminates every process in it, so the
+// caller should keep it alive for as long as cmd is expected to run.
+func applyJobObjectLimits(cmd *exec.Cmd, limits ResourceLimits) (windows.Handle, error) {
+	if limits.CPUTime == 0 && limits.MaxMemory == 0 {
+		return 0, nil
+	}
+
+	job, err := windows.CreateJobObject(nil, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create job object: %w", err)
+	}
+
+	info := windows.JOBOBJECT_EXTENDED_LIMIT_INFORMATION{}
+	info.BasicLimitInformation.LimitFlags |= windows.JOB_OBJECT_LIMIT_KILL_ON_JOB_CLOSE
+	if limits.CPUTime > 0 {
+		info.BasicLimitInformation.LimitFlags |= windows.JOB_OBJECT_LIMIT_PROCESS_TIME
+		info.BasicLimitInformation.PerProcessUserTimeLimit = int64(limits.CPUTime / 100) // 100ns units
+	}
+	if limits.MaxMemory > 0 {
+		info.BasicLimitInformation.LimitFlags |= windows.JOB_OBJECT_LIMIT_PROCESS_MEMORY
+		info.ProcessMemoryLimit = uintptr(limits.MaxMemory)
+	}
+
+	if _, err := windows.SetInformationJobObject(
+		job,
+		windows.JobObjectExtendedLimitInformation,
+		uintptr(unsafe.Pointer(&info)),
+		uint32(unsafe.Sizeof(info)),
+	); err != nil {
+		windows.CloseHandle(job)
+		return 0, fmt.Errorf("failed to set job object limits: %w", err)
+	}
+
+	if cmd.Process == nil {
+		windows.CloseHandle(job)
+		return 0, fmt.Errorf("cannot assign job object before process starts")
+	}
+
+	handle, err := windows.OpenProcess(windows.PROCESS_SET_QUOTA|windows.PROCESS_TERMINATE, false, uint32(cmd.Process.Pid))
+	if err != nil {
+		windows.CloseHandle(job)
+		return 0, fmt.Errorf("failed to open process handle: %w", err)
+	}
+	defer windows.CloseHandle(handle)
+
+	if err := windows.AssignProcessToJobObject(job, handle); err != nil {
+		windows.CloseHandle(job)
+		return 0, fmt.Errorf("failed to assign process to job object: %w", err)
+	}
+
+	return job, nil
+}
+
+// attachPlatformLimits assigns cmd's already-started process to a Job
+// Object enforcing limits.CPUTime/MaxMemory, returning a cleanup func
+// that closes the Job Object (and so terminates anything still in it).
+func attachPlatformLimits(cmd *exec.Cmd, limits ResourceLimits) (cleanup func(), err error) {
+	job, err := applyJobObjectLimits(cmd, limits)
+	if err != nil || job == 0 {
+		return nil, err
+	}
+	return func() { windows.CloseHandle(job) }, nil
+}
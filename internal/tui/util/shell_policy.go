@@ -0,0 +1,130 @@
+package util
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// StrictnessLevel labels how strict an ExecPolicy is. Check and
+// ExecShellWithPolicy do not read it: what is enforced comes from the
+// policy's Allowlist, Denylist, Limits, and Sandbox fields.
+type StrictnessLevel int
+
+const (
+	// StrictnessUser labels DefaultUserPolicy, for commands the user
+	// typed themselves: that policy sets only the denylist.
+	StrictnessUser StrictnessLevel = iota
+	// StrictnessAgent labels DefaultAgentPolicy, for commands an agent
+	// tool wants to run: that policy sets the allowlist, denylist,
+	// resource limits, and sandboxing.
+	StrictnessAgent
+)
+
+// ResourceLimits bounds a single command's CPU time, memory, and wall-clock
+// runtime. A zero value means "no limit" for that dimension.
+type ResourceLimits struct {
+	CPUTime   time.Duration
+	MaxMemory int64 // bytes
+	WallClock time.Duration
+}
+
+// ExecPolicy controls what ExecShell allows a given command string to do.
+// Callers build a stricter policy for agent-initiated commands than for
+// commands the user typed directly into the shell.
+type ExecPolicy struct {
+	Strictness StrictnessLevel
+
+	// Allowlist, if non-empty, requires argv[0] or the full command to
+	// match at least one pattern. Denylist rejects a match regardless of
+	// the allowlist.
+	Allowlist []*regexp.Regexp
+	Denylist  []*regexp.Regexp
+
+	Limits ResourceLimits
+
+	// Sandbox wraps the command with unshare/nsjail on Linux when one is
+	// available on PATH. It's a no-op on other platforms.
+	Sandbox bool
+}
+
+// defaultDenylist blocks commands that are destructive or that exfiltrate
+// the shell to somewhere we can't audit (a pipe into another shell), no
+// matter how permissive the rest of the policy is.
+var defaultDenylist = []*regexp.Regexp{
+	regexp.MustCompile(`^rm$`),
+	regexp.MustCompile(`^dd$`),
+	regexp.MustCompile(`^mkfs(\.\w+)?$`),
+	regexp.MustCompile(`^shutdown$`),
+	regexp.MustCompile(`^reboot$`),
+	regexp.MustCompile(`^init$`),
+	regexp.MustCompile(`:\(\)\s*\{.*:\|:.*\}`), // fork bomb
+	regexp.MustCompile(`>\s*/dev/sd[a-z]`),
+	regexp.MustCompile(`\b(curl|wget)\b.*\|\s*(sh|bash|zsh)\b`),
+	regexp.MustCompile(`\|\s*(sh|bash|zsh)\b`),
+}
+
+// defaultAgentAllowlist covers the read-only and everyday dev commands an
+// agent tool needs; anything not on it is rejected outright.
+var defaultAgentAllowlist = []*regexp.Regexp{
+	regexp.MustCompile(`^(ls|cat|head|tail|wc|find|grep|rg|sed|awk|diff|file|stat|pwd|echo|printf)$`),
+	regexp.MustCompile(`^(git|go|gofmt|npm|npx|yarn|pnpm|node|python|python3|pip|pip3)$`),
+	regexp.MustCompile(`^(mkdir|touch|cp|mv)$`),
+}
+
+// DefaultUserPolicy is applied to commands the user types directly; it
+// enforces only the denylist, trusting the user with everything else.
+func DefaultUserPolicy() ExecPolicy {
+	return ExecPolicy{
+		Strictness: StrictnessUser,
+		Denylist:   defaultDenylist,
+	}
+}
+
+// DefaultAgentPolicy is applied to commands run on behalf of an agent
+// tool; it enforces the allowlist and denylist, resource limits, and
+// sandboxing.
+func DefaultAgentPolicy() ExecPolicy {
+	return ExecPolicy{
+		Strictness: StrictnessAgent,
+		Allowlist:  defaultAgentAllowlist,
+		Denylist:   defaultDenylist,
+		Sandbox:    true,
+		Limits: ResourceLimits{
+			CPUTime:   30 * time.Second,
+			MaxMemory: 1 << 30, // 1 GiB
+			WallClock: 2 * time.Minute,
+		},
+	}
+}
+
+// PolicyViolation is returned by ExecPolicy.Check when a command is
+// rejected, and is what ExecShell surfaces as a tea.Cmd error.
+type PolicyViolation struct {
+	Command string
+	Reason  string
+}
+
+func (v *PolicyViolation) Error() string {
+	return fmt.Sprintf("command rejected by policy: %s (%s)", v.Command, v.Reason)
+}
+
+// Check validates cmdStr and its argv[0] against the policy's allow and
+// deny lists, returning a *PolicyViolation if it's rejected.
+func (p ExecPolicy) Check(cmdStr string, argv0 string) error {
+	for _, pattern := range p.Denylist {
+		if pattern.MatchString(argv0) || pattern.MatchString(cmdStr) {
+			return &PolicyViolation{Command: cmdStr, Reason: fmt.Sprintf("matches denylist pattern %q", pattern.String())}
+		}
+	}
+
+	if len(p.Allowlist) == 0 {
+		return nil
+	}
+	for _, pattern := range p.Allowlist {
+		if pattern.MatchString(argv0) || pattern.MatchString(cmdStr) {
+			return nil
+		}
+	}
+	return &PolicyViolation{Command: cmdStr, Reason: "does not match any allowlist pattern"}
+}
@@ -0,0 +1,20 @@
+//go:build linux
+
+package util
+
+import "os/exec"
+
+// wrapWithSandbox re-execs fields under nsjail or unshare, whichever is
+// available on PATH, isolating the command's network and PID namespaces.
+// It returns fields unchanged if neither tool is installed.
+func wrapWithSandbox(fields []string) []string {
+	if path, err := exec.LookPath("nsjail"); err == nil {
+		args := append([]string{path, "--mode", "o", "--disable_clone_newnet=false", "--"}, fields...)
+		return args
+	}
+	if path, err := exec.LookPath("unshare"); err == nil {
+		args := append([]string{path, "--net", "--pid", "--mount-proc", "--fork", "--"}, fields...)
+		return args
+	}
+	return fields
+}
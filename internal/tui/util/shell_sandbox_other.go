@@ -0,0 +1,9 @@
+//go:build !linux
+
+package util
+
+// wrapWithSandbox is a no-op outside Linux: unshare and nsjail are both
+// Linux-specific namespace tools.
+func wrapWithSandbox(fields []string) []string {
+	return fields
+}